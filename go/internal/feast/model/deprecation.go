@@ -0,0 +1,143 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// DeprecationMode controls how the model package reacts when it encounters
+// a deprecated construct, such as an Entity that still only sets the legacy
+// JoinKey field. It is set process-wide via the FEAST_DEPRECATION_MODE
+// environment variable, which NewEntityFromProto and Validate read on every
+// call; there is no per-call override.
+type DeprecationMode int
+
+const (
+	// DeprecationSilent ignores deprecated usage entirely.
+	DeprecationSilent DeprecationMode = iota
+	// DeprecationWarn (the default) records a warning diagnostic but does
+	// not fail.
+	DeprecationWarn
+	// DeprecationError turns deprecated usage into a hard failure.
+	DeprecationError
+)
+
+// DeprecationModeEnvVar is the environment variable used to configure the
+// process-wide DeprecationMode. Accepted values are "silent", "warn" and
+// "error" (case-insensitive); any other value falls back to DeprecationWarn.
+const DeprecationModeEnvVar = "FEAST_DEPRECATION_MODE"
+
+func (m DeprecationMode) String() string {
+	switch m {
+	case DeprecationSilent:
+		return "silent"
+	case DeprecationError:
+		return "error"
+	default:
+		return "warn"
+	}
+}
+
+// currentDeprecationMode returns the DeprecationMode configured via
+// FEAST_DEPRECATION_MODE, defaulting to DeprecationWarn.
+func currentDeprecationMode() DeprecationMode {
+	switch strings.ToLower(os.Getenv(DeprecationModeEnvVar)) {
+	case "silent":
+		return DeprecationSilent
+	case "error":
+		return DeprecationError
+	default:
+		return DeprecationWarn
+	}
+}
+
+// Severity classifies how serious a Diagnostic is.
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "ERROR"
+	case SeverityWarning:
+		return "WARNING"
+	default:
+		return "INFO"
+	}
+}
+
+// Diagnostic describes a single issue found while validating a registry
+// object, such as an Entity relying on a deprecated field.
+type Diagnostic struct {
+	Severity     Severity
+	Code         string
+	Message      string
+	SuggestedFix string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("[%s] %s: %s (fix: %s)", d.Severity, d.Code, d.Message, d.SuggestedFix)
+}
+
+// DiagLegacyJoinKey is the diagnostic code emitted when an Entity is defined
+// using only the deprecated JoinKey field instead of JoinKeys.
+const DiagLegacyJoinKey = "FEAST_ENTITY_LEGACY_JOIN_KEY"
+
+// Validate checks e for deprecated usage and returns the diagnostics found.
+// The severity of each diagnostic follows the process-wide DeprecationMode
+// (FEAST_DEPRECATION_MODE): DeprecationSilent suppresses all diagnostics,
+// DeprecationWarn reports them as warnings, and DeprecationError reports
+// them as errors.
+func (e *Entity) Validate() []Diagnostic {
+	mode := currentDeprecationMode()
+	if mode == DeprecationSilent {
+		return nil
+	}
+
+	var diagnostics []Diagnostic
+	if e.legacyWireFormat {
+		severity := SeverityWarning
+		if mode == DeprecationError {
+			severity = SeverityError
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: severity,
+			Code:     DiagLegacyJoinKey,
+			Message:  fmt.Sprintf("entity %q is defined using only the deprecated JoinKey field", e.Name),
+			SuggestedFix: fmt.Sprintf(
+				"replace JoinKey: %q with JoinKeys: map[string]types.ValueType_Enum{%q: <value type>}",
+				e.JoinKey, e.JoinKey,
+			),
+		})
+	}
+	return diagnostics
+}
+
+// ValidateEntities runs Validate against every entity in entities and
+// returns the combined diagnostics. It is the entry point applications
+// embedding the Go feature server can call to implement a "feast validate"
+// style check of their loaded registry.
+func ValidateEntities(entities []*Entity) []Diagnostic {
+	var diagnostics []Diagnostic
+	for _, e := range entities {
+		diagnostics = append(diagnostics, e.Validate()...)
+	}
+	return diagnostics
+}
+
+// hasErrorDiagnostic reports whether diagnostics contains at least one
+// SeverityError entry.
+func hasErrorDiagnostic(diagnostics []Diagnostic) bool {
+	for _, d := range diagnostics {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package model
+
+import (
+	"os"
+	"testing"
+
+	"github.com/feast-dev/feast/go/protos/feast/types"
+
+	"github.com/feast-dev/feast/go/protos/feast/core"
+)
+
+func withDeprecationMode(t *testing.T, mode string) {
+	t.Helper()
+	old, hadOld := os.LookupEnv(DeprecationModeEnvVar)
+	if err := os.Setenv(DeprecationModeEnvVar, mode); err != nil {
+		t.Fatalf("os.Setenv: %v", err)
+	}
+	t.Cleanup(func() {
+		if hadOld {
+			os.Setenv(DeprecationModeEnvVar, old)
+		} else {
+			os.Unsetenv(DeprecationModeEnvVar)
+		}
+	})
+}
+
+func TestNewEntityFromProtoDualWriteIsNotLegacy(t *testing.T) {
+	withDeprecationMode(t, "error")
+
+	// A transitional entity that dual-writes the deprecated JoinKey
+	// alongside a fully populated JoinKeys map must not be treated as
+	// legacy-only: JoinKeys, not JoinKey, is the source of truth here.
+	proto := &core.Entity{
+		Spec: &core.EntitySpecV2{
+			Name:    "driver",
+			JoinKey: "driver_id",
+			JoinKeys: map[string]*core.EntitySpecV2_JoinKeySpec{
+				"driver_id": {ValueType: types.ValueType_INT64},
+			},
+		},
+	}
+
+	entity, err := NewEntityFromProto(proto)
+	if err != nil {
+		t.Fatalf("expected dual-write entity to load under DeprecationError, got: %v", err)
+	}
+	if diagnostics := entity.Validate(); len(diagnostics) != 0 {
+		t.Fatalf("expected no diagnostics for a dual-write entity, got: %v", diagnostics)
+	}
+}
+
+func TestNewEntityFromProtoLegacyOnlyFailsUnderError(t *testing.T) {
+	withDeprecationMode(t, "error")
+
+	proto := &core.Entity{
+		Spec: &core.EntitySpecV2{
+			Name:      "driver",
+			JoinKey:   "driver_id",
+			ValueType: types.ValueType_INT64,
+		},
+	}
+
+	if _, err := NewEntityFromProto(proto); err == nil {
+		t.Fatal("expected legacy-only entity to fail validation under DeprecationError")
+	}
+}
+
+func legacyEntity(name, joinKey string) *Entity {
+	entity, err := UpgradeEntity(&EntityV1{
+		Name:      name,
+		JoinKey:   joinKey,
+		ValueType: types.ValueType_INT64,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return entity
+}
+
+func TestValidateDefaultModeIsWarn(t *testing.T) {
+	// No FEAST_DEPRECATION_MODE set: currentDeprecationMode should default
+	// to DeprecationWarn.
+	old, hadOld := os.LookupEnv(DeprecationModeEnvVar)
+	os.Unsetenv(DeprecationModeEnvVar)
+	t.Cleanup(func() {
+		if hadOld {
+			os.Setenv(DeprecationModeEnvVar, old)
+		}
+	})
+
+	diagnostics := legacyEntity("driver", "driver_id").Validate()
+	if len(diagnostics) != 1 {
+		t.Fatalf("len(diagnostics) = %d, want 1", len(diagnostics))
+	}
+
+	diag := diagnostics[0]
+	if diag.Severity != SeverityWarning {
+		t.Fatalf("Severity = %v, want SeverityWarning", diag.Severity)
+	}
+	if diag.Code != DiagLegacyJoinKey {
+		t.Fatalf("Code = %q, want %q", diag.Code, DiagLegacyJoinKey)
+	}
+	if diag.Message == "" {
+		t.Fatal("Message should not be empty")
+	}
+	wantFix := `replace JoinKey: "driver_id" with JoinKeys: map[string]types.ValueType_Enum{"driver_id": <value type>}`
+	if diag.SuggestedFix != wantFix {
+		t.Fatalf("SuggestedFix = %q, want %q", diag.SuggestedFix, wantFix)
+	}
+}
+
+func TestValidateErrorMode(t *testing.T) {
+	withDeprecationMode(t, "error")
+
+	diagnostics := legacyEntity("driver", "driver_id").Validate()
+	if len(diagnostics) != 1 {
+		t.Fatalf("len(diagnostics) = %d, want 1", len(diagnostics))
+	}
+	if diagnostics[0].Severity != SeverityError {
+		t.Fatalf("Severity = %v, want SeverityError", diagnostics[0].Severity)
+	}
+}
+
+func TestValidateSilentModeSuppressesDiagnostics(t *testing.T) {
+	withDeprecationMode(t, "silent")
+
+	if diagnostics := legacyEntity("driver", "driver_id").Validate(); diagnostics != nil {
+		t.Fatalf("expected no diagnostics in DeprecationSilent, got: %v", diagnostics)
+	}
+}
+
+func TestValidateEntitiesAggregatesAcrossEntities(t *testing.T) {
+	withDeprecationMode(t, "warn")
+
+	migrated := &Entity{
+		Name:     "rider",
+		JoinKeys: map[string]types.ValueType_Enum{"rider_id": types.ValueType_INT64},
+	}
+	entities := []*Entity{
+		legacyEntity("driver", "driver_id"),
+		migrated,
+		legacyEntity("vehicle", "vehicle_id"),
+	}
+
+	diagnostics := ValidateEntities(entities)
+	if len(diagnostics) != 2 {
+		t.Fatalf("len(diagnostics) = %d, want 2 (one per legacy entity, none for the migrated one)", len(diagnostics))
+	}
+	for _, d := range diagnostics {
+		if d.Code != DiagLegacyJoinKey {
+			t.Fatalf("Code = %q, want %q", d.Code, DiagLegacyJoinKey)
+		}
+	}
+}
@@ -1,33 +1,228 @@
 package model
 
 import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
 	"github.com/feast-dev/feast/go/protos/feast/core"
 	"github.com/feast-dev/feast/go/protos/feast/types"
 )
 
-type Entity struct {
-	Name     string
-	JoinKey  string                          // DEPRECATED: Use JoinKeys instead
-	JoinKeys map[string]types.ValueType_Enum // New field for multiple join keys (key: join_key_name, value: value_type)
+// NewEntityFromProto builds an Entity from its registry proto representation,
+// detecting on the wire whether proto was written in the legacy V1 shape
+// (Spec.JoinKey/Spec.ValueType only) or the current V2 shape (Spec.JoinKeys),
+// and upgrading V1 data to V2 via UpgradeEntity. This allows the registry
+// reader to load proto blobs written by older Feast deployments without
+// losing information.
+//
+// If the entity relies solely on the deprecated JoinKey field and the
+// process-wide DeprecationMode (see FEAST_DEPRECATION_MODE) is
+// DeprecationError, it returns an error instead of loading the entity.
+func NewEntityFromProto(proto *core.Entity) (*Entity, error) {
+	var (
+		entity *Entity
+		err    error
+	)
+	if len(proto.Spec.JoinKeys) > 0 {
+		entity, err = UpgradeEntity(entityV2FromProto(proto))
+	} else {
+		entity, err = UpgradeEntity(&EntityV1{
+			Name:      proto.Spec.Name,
+			JoinKey:   proto.Spec.JoinKey,
+			ValueType: proto.Spec.ValueType,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if diagnostics := entity.Validate(); hasErrorDiagnostic(diagnostics) {
+		return nil, fmt.Errorf("entity %q failed validation: %v", entity.Name, diagnostics)
+	}
+
+	return entity, nil
 }
 
-func NewEntityFromProto(proto *core.Entity) *Entity {
-	entity := &Entity{
-		Name:     proto.Spec.Name,
-		JoinKey:  proto.Spec.JoinKey, // Backward compatibility
-		JoinKeys: make(map[string]types.ValueType_Enum),
+// entityV2FromProto builds an EntityV2 from a registry proto already known
+// to be on the current wire format (Spec.JoinKeys is populated).
+func entityV2FromProto(proto *core.Entity) *EntityV2 {
+	entity := &EntityV2{
+		Name:             proto.Spec.Name,
+		JoinKey:          proto.Spec.JoinKey, // Backward compatibility
+		JoinKeys:         make(map[string]types.ValueType_Enum),
+		JoinKeyProtoFQNs: make(map[string]string),
 	}
 
-	// Check if the new join_keys format is available
-	if len(proto.Spec.JoinKeys) > 0 {
-		// New format: use join_keys map with value types
-		for joinKeyName, joinKeySpec := range proto.Spec.JoinKeys {
-			entity.JoinKeys[joinKeyName] = joinKeySpec.ValueType
+	for joinKeyName, joinKeySpec := range proto.Spec.JoinKeys {
+		entity.JoinKeys[joinKeyName] = joinKeySpec.ValueType
+		if fqn := joinKeySpec.GetProtoFqn(); fqn != "" {
+			entity.JoinKeyProtoFQNs[joinKeyName] = fqn
 		}
-	} else {
-		// Legacy format: use single join_key
-		entity.JoinKeys[proto.Spec.JoinKey] = proto.Spec.ValueType
 	}
 
 	return entity
 }
+
+// JoinKeyNames returns the entity's join key names sorted alphabetically.
+// Composite entity keys must be built in a deterministic order, so callers
+// that assemble a multi-key EntityKey proto should iterate in this order
+// rather than over the JoinKeys map directly.
+func (e *Entity) JoinKeyNames() []string {
+	names := make([]string, 0, len(e.JoinKeys))
+	for name := range e.JoinKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateEntityValues checks that entityValues supplies exactly the join
+// keys declared by this entity (single-key or composite) and that each
+// supplied value's type matches the ValueType_Enum declared for that key in
+// JoinKeys. Declared keys are checked in sorted order so the returned error
+// is deterministic regardless of map iteration order; any key present in
+// entityValues but not declared on the entity is also rejected.
+func (e *Entity) ValidateEntityValues(entityValues map[string]*types.Value) error {
+	for _, joinKey := range e.JoinKeyNames() {
+		expectedType := e.JoinKeys[joinKey]
+		value, ok := entityValues[joinKey]
+		if !ok || value == nil {
+			return fmt.Errorf("entity %s: missing required join key %q", e.Name, joinKey)
+		}
+		if expectedType == types.ValueType_PROTO_MESSAGE {
+			if err := e.validateProtoMessageValue(joinKey, value); err != nil {
+				return fmt.Errorf("entity %s: join key %q: %w", e.Name, joinKey, err)
+			}
+			continue
+		}
+		if !valueMatchesType(value, expectedType) {
+			return fmt.Errorf("entity %s: join key %q expects value type %s, got %s", e.Name, joinKey, expectedType, describeValueType(value))
+		}
+	}
+	for joinKey := range entityValues {
+		if _, declared := e.JoinKeys[joinKey]; !declared {
+			return fmt.Errorf("entity %s: unexpected join key %q is not declared in JoinKeys", e.Name, joinKey)
+		}
+	}
+	return nil
+}
+
+// validateProtoMessageValue checks that value is bytes that actually
+// deserialize as the proto type registered for joinKey in
+// JoinKeyProtoFQNs, via RegisterProtoType. This is stricter than the
+// generic BYTES↔PROTO_MESSAGE wire-type check: it confirms the bytes are
+// compatible with the declared proto type, not just that they're bytes.
+func (e *Entity) validateProtoMessageValue(joinKey string, value *types.Value) error {
+	bytesVal, ok := value.Val.(*types.Value_BytesVal)
+	if !ok {
+		return fmt.Errorf("expects value type PROTO_MESSAGE (bytes), got %s", describeValueType(value))
+	}
+
+	fqn, ok := e.JoinKeyProtoFQNs[joinKey]
+	if !ok {
+		return fmt.Errorf("declared as PROTO_MESSAGE but has no entry in JoinKeyProtoFQNs")
+	}
+
+	msg, err := newProtoMessage(fqn)
+	if err != nil {
+		return fmt.Errorf("proto type %q: %w", fqn, err)
+	}
+	if err := proto.Unmarshal(bytesVal.BytesVal, msg); err != nil {
+		return fmt.Errorf("value does not deserialize as registered proto type %q: %w", fqn, err)
+	}
+	return nil
+}
+
+// SerializeEntityKey builds the canonical composite EntityKey proto for this
+// entity from entityValues: join key names sorted alphabetically, with
+// EntityValues aligned positionally to JoinKeys so the same logical entity
+// always serializes to the same bytes regardless of map iteration order.
+// entityValues is validated via ValidateEntityValues first, so a missing,
+// mistyped, or unexpected key produces the same clear error lookups get
+// from validation alone.
+func (e *Entity) SerializeEntityKey(entityValues map[string]*types.Value) (*types.EntityKey, error) {
+	if err := e.ValidateEntityValues(entityValues); err != nil {
+		return nil, err
+	}
+
+	joinKeys := e.JoinKeyNames()
+	entityKeyValues := make([]*types.Value, len(joinKeys))
+	for i, joinKey := range joinKeys {
+		entityKeyValues[i] = entityValues[joinKey]
+	}
+
+	return &types.EntityKey{
+		JoinKeys:     joinKeys,
+		EntityValues: entityKeyValues,
+	}, nil
+}
+
+// valueMatchesType reports whether value's wire representation is
+// compatible with valueType. ValueType_PROTO_MESSAGE is not handled here:
+// it additionally needs the join key's registered proto FQN to confirm the
+// bytes deserialize correctly, so it goes through validateProtoMessageValue
+// instead.
+func valueMatchesType(value *types.Value, valueType types.ValueType_Enum) bool {
+	switch valueType {
+	case types.ValueType_BYTES:
+		_, ok := value.Val.(*types.Value_BytesVal)
+		return ok
+	case types.ValueType_STRING:
+		_, ok := value.Val.(*types.Value_StringVal)
+		return ok
+	case types.ValueType_INT32:
+		_, ok := value.Val.(*types.Value_Int32Val)
+		return ok
+	case types.ValueType_INT64:
+		_, ok := value.Val.(*types.Value_Int64Val)
+		return ok
+	case types.ValueType_DOUBLE:
+		_, ok := value.Val.(*types.Value_DoubleVal)
+		return ok
+	case types.ValueType_FLOAT:
+		_, ok := value.Val.(*types.Value_FloatVal)
+		return ok
+	case types.ValueType_BOOL:
+		_, ok := value.Val.(*types.Value_BoolVal)
+		return ok
+	case types.ValueType_UNIX_TIMESTAMP:
+		_, ok := value.Val.(*types.Value_UnixTimestampVal)
+		return ok
+	case types.ValueType_PROTO_ENUM:
+		// Enum values round-trip as either int32 (proto) or string (JSON);
+		// int64 values are accepted as well.
+		switch value.Val.(type) {
+		case *types.Value_Int32Val, *types.Value_Int64Val, *types.Value_StringVal:
+			return true
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+}
+
+func describeValueType(value *types.Value) string {
+	switch value.Val.(type) {
+	case *types.Value_BytesVal:
+		return "BYTES"
+	case *types.Value_StringVal:
+		return "STRING"
+	case *types.Value_Int32Val:
+		return "INT32"
+	case *types.Value_Int64Val:
+		return "INT64"
+	case *types.Value_DoubleVal:
+		return "DOUBLE"
+	case *types.Value_FloatVal:
+		return "FLOAT"
+	case *types.Value_BoolVal:
+		return "BOOL"
+	case *types.Value_UnixTimestampVal:
+		return "UNIX_TIMESTAMP"
+	default:
+		return "UNKNOWN"
+	}
+}
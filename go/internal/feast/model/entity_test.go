@@ -0,0 +1,164 @@
+package model
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/feast-dev/feast/go/protos/feast/types"
+)
+
+func twoKeyEntity() *Entity {
+	return &Entity{
+		Name: "driver_rider",
+		JoinKeys: map[string]types.ValueType_Enum{
+			"driver_id": types.ValueType_INT64,
+			"rider_id":  types.ValueType_INT64,
+		},
+	}
+}
+
+func threeKeyEntity() *Entity {
+	return &Entity{
+		Name: "driver_rider_trip",
+		JoinKeys: map[string]types.ValueType_Enum{
+			"driver_id": types.ValueType_INT64,
+			"rider_id":  types.ValueType_INT64,
+			"trip_id":   types.ValueType_STRING,
+		},
+	}
+}
+
+func TestSerializeEntityKeyTwoKeysDeterministicOrder(t *testing.T) {
+	entity := twoKeyEntity()
+	values := map[string]*types.Value{
+		"rider_id":  {Val: &types.Value_Int64Val{Int64Val: 2}},
+		"driver_id": {Val: &types.Value_Int64Val{Int64Val: 1}},
+	}
+
+	entityKey, err := entity.SerializeEntityKey(values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantJoinKeys := []string{"driver_id", "rider_id"}
+	if len(entityKey.JoinKeys) != len(wantJoinKeys) {
+		t.Fatalf("JoinKeys = %v, want %v", entityKey.JoinKeys, wantJoinKeys)
+	}
+	for i, want := range wantJoinKeys {
+		if entityKey.JoinKeys[i] != want {
+			t.Fatalf("JoinKeys[%d] = %q, want %q", i, entityKey.JoinKeys[i], want)
+		}
+	}
+	if entityKey.EntityValues[0].GetInt64Val() != 1 || entityKey.EntityValues[1].GetInt64Val() != 2 {
+		t.Fatalf("EntityValues not aligned to sorted JoinKeys: %v", entityKey.EntityValues)
+	}
+}
+
+func TestSerializeEntityKeyThreeKeysDeterministicOrder(t *testing.T) {
+	entity := threeKeyEntity()
+	values := map[string]*types.Value{
+		"trip_id":   {Val: &types.Value_StringVal{StringVal: "trip-1"}},
+		"rider_id":  {Val: &types.Value_Int64Val{Int64Val: 2}},
+		"driver_id": {Val: &types.Value_Int64Val{Int64Val: 1}},
+	}
+
+	entityKey, err := entity.SerializeEntityKey(values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantJoinKeys := []string{"driver_id", "rider_id", "trip_id"}
+	for i, want := range wantJoinKeys {
+		if entityKey.JoinKeys[i] != want {
+			t.Fatalf("JoinKeys[%d] = %q, want %q", i, entityKey.JoinKeys[i], want)
+		}
+	}
+	if entityKey.EntityValues[2].GetStringVal() != "trip-1" {
+		t.Fatalf("EntityValues[2] = %v, want trip-1", entityKey.EntityValues[2])
+	}
+}
+
+func TestSerializeEntityKeyMissingKey(t *testing.T) {
+	entity := twoKeyEntity()
+	values := map[string]*types.Value{
+		"driver_id": {Val: &types.Value_Int64Val{Int64Val: 1}},
+	}
+
+	if _, err := entity.SerializeEntityKey(values); err == nil {
+		t.Fatal("expected error for missing join key, got nil")
+	}
+}
+
+func TestValidateEntityValuesNilValueDoesNotPanic(t *testing.T) {
+	entity := twoKeyEntity()
+	values := map[string]*types.Value{
+		"driver_id": nil,
+		"rider_id":  {Val: &types.Value_Int64Val{Int64Val: 2}},
+	}
+
+	err := entity.ValidateEntityValues(values)
+	if err == nil {
+		t.Fatal("expected error for nil join key value, got nil")
+	}
+}
+
+func TestSerializeEntityKeyUnexpectedKey(t *testing.T) {
+	entity := twoKeyEntity()
+	values := map[string]*types.Value{
+		"driver_id": {Val: &types.Value_Int64Val{Int64Val: 1}},
+		"rider_id":  {Val: &types.Value_Int64Val{Int64Val: 2}},
+		"trip_id":   {Val: &types.Value_StringVal{StringVal: "trip-1"}},
+	}
+
+	if _, err := entity.SerializeEntityKey(values); err == nil {
+		t.Fatal("expected error for undeclared join key, got nil")
+	}
+}
+
+func TestValidateEntityValuesProtoMessage(t *testing.T) {
+	const fqn = "google.protobuf.StringValue"
+	if err := RegisterProtoType(fqn, &wrapperspb.StringValue{}); err != nil {
+		t.Fatalf("RegisterProtoType: %v", err)
+	}
+
+	entity := &Entity{
+		Name: "singer",
+		JoinKeys: map[string]types.ValueType_Enum{
+			"singer_info": types.ValueType_PROTO_MESSAGE,
+		},
+		JoinKeyProtoFQNs: map[string]string{
+			"singer_info": fqn,
+		},
+	}
+
+	serialized, err := proto.Marshal(wrapperspb.String("feast"))
+	if err != nil {
+		t.Fatalf("proto.Marshal: %v", err)
+	}
+
+	values := map[string]*types.Value{
+		"singer_info": {Val: &types.Value_BytesVal{BytesVal: serialized}},
+	}
+	if err := entity.ValidateEntityValues(values); err != nil {
+		t.Fatalf("unexpected error validating registered proto message: %v", err)
+	}
+
+	values["singer_info"] = &types.Value{Val: &types.Value_BytesVal{BytesVal: []byte("not a valid StringValue")}}
+	if err := entity.ValidateEntityValues(values); err == nil {
+		t.Fatal("expected error for bytes that do not deserialize as the registered proto type")
+	}
+}
+
+func TestSerializeEntityKeyTypeMismatch(t *testing.T) {
+	entity := twoKeyEntity()
+	values := map[string]*types.Value{
+		"driver_id": {Val: &types.Value_StringVal{StringVal: "not-an-int"}},
+		"rider_id":  {Val: &types.Value_Int64Val{Int64Val: 2}},
+	}
+
+	if _, err := entity.SerializeEntityKey(values); err == nil {
+		t.Fatal("expected error for mismatched value type, got nil")
+	}
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/feast-dev/feast/go/protos/feast/types"
+)
+
+// EntityV1 mirrors the legacy registry proto shape, where an entity declares
+// exactly one join key via Spec.JoinKey/Spec.ValueType. It exists so that
+// registries written by older Feast deployments can be loaded without loss
+// of information, and upgraded to EntityV2 via UpgradeEntity.
+type EntityV1 struct {
+	Name      string
+	JoinKey   string
+	ValueType types.ValueType_Enum
+}
+
+// EntityV2 is the current entity schema, supporting composite join keys
+// (JoinKeys) as well as proto message/enum join key types
+// (JoinKeyProtoFQNs). Entity is kept as an alias to EntityV2 so existing
+// code and call sites keep compiling as the schema evolves; a future V3
+// would repoint the alias rather than break Entity callers.
+type EntityV2 struct {
+	Name     string
+	JoinKey  string                          // DEPRECATED: Use JoinKeys instead
+	JoinKeys map[string]types.ValueType_Enum // New field for multiple join keys (key: join_key_name, value: value_type)
+
+	// JoinKeyProtoFQNs holds the fully qualified proto type name (e.g.
+	// "examples.SingerInfo") for any join key declared with ValueType_PROTO_MESSAGE
+	// or ValueType_PROTO_ENUM. Join keys using a plain scalar type have no entry here.
+	JoinKeyProtoFQNs map[string]string
+
+	// legacyWireFormat records whether this entity was upgraded from the V1
+	// wire shape (Spec.JoinKey/Spec.ValueType only), as opposed to JoinKey
+	// merely being set alongside a populated JoinKeys for backward
+	// compatibility on an already-migrated V2 entity. Validate uses this,
+	// rather than JoinKey != "", to decide whether the legacy diagnostic
+	// applies.
+	legacyWireFormat bool
+}
+
+// Entity is a stable alias for the current major entity schema version.
+type Entity = EntityV2
+
+// UpgradeEntity converts any supported entity schema version into the
+// current EntityV2 shape. It accepts *EntityV1, *EntityV2 (returned
+// unchanged), or a value of a future version once added, and returns an
+// error for anything else.
+func UpgradeEntity(entity any) (*EntityV2, error) {
+	switch e := entity.(type) {
+	case *EntityV2:
+		return e, nil
+	case *EntityV1:
+		return &EntityV2{
+			Name:             e.Name,
+			JoinKey:          e.JoinKey,
+			JoinKeys:         map[string]types.ValueType_Enum{e.JoinKey: e.ValueType},
+			JoinKeyProtoFQNs: make(map[string]string),
+			legacyWireFormat: true,
+		}, nil
+	default:
+		return nil, fmt.Errorf("UpgradeEntity: unsupported entity schema version %T", entity)
+	}
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/feast-dev/feast/go/protos/feast/core"
+	"github.com/feast-dev/feast/go/protos/feast/types"
+)
+
+func TestUpgradeEntityV2Passthrough(t *testing.T) {
+	v2 := &EntityV2{
+		Name:     "driver",
+		JoinKeys: map[string]types.ValueType_Enum{"driver_id": types.ValueType_INT64},
+	}
+
+	upgraded, err := UpgradeEntity(v2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if upgraded != v2 {
+		t.Fatal("UpgradeEntity(*EntityV2) should return the same instance unchanged")
+	}
+}
+
+func TestUpgradeEntityV1(t *testing.T) {
+	v1 := &EntityV1{
+		Name:      "driver",
+		JoinKey:   "driver_id",
+		ValueType: types.ValueType_INT64,
+	}
+
+	upgraded, err := UpgradeEntity(v1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if upgraded.JoinKeys["driver_id"] != types.ValueType_INT64 {
+		t.Fatalf("JoinKeys[driver_id] = %v, want INT64", upgraded.JoinKeys["driver_id"])
+	}
+	if !upgraded.legacyWireFormat {
+		t.Fatal("entity upgraded from EntityV1 should be marked legacyWireFormat")
+	}
+}
+
+func TestUpgradeEntityUnsupportedType(t *testing.T) {
+	if _, err := UpgradeEntity("not an entity"); err == nil {
+		t.Fatal("expected error for an unsupported entity schema version, got nil")
+	}
+}
+
+func TestNewEntityFromProtoPopulatesJoinKeyProtoFQNs(t *testing.T) {
+	const fqn = "examples.SingerInfo"
+	proto := &core.Entity{
+		Spec: &core.EntitySpecV2{
+			Name: "singer",
+			JoinKeys: map[string]*core.EntitySpecV2_JoinKeySpec{
+				"singer_info": {ValueType: types.ValueType_PROTO_MESSAGE, ProtoFqn: fqn},
+			},
+		},
+	}
+
+	entity, err := NewEntityFromProto(proto)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := entity.JoinKeyProtoFQNs["singer_info"]; got != fqn {
+		t.Fatalf("JoinKeyProtoFQNs[singer_info] = %q, want %q", got, fqn)
+	}
+	if entity.legacyWireFormat {
+		t.Fatal("entity loaded from the V2 wire format should not be marked legacyWireFormat")
+	}
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// protoRegistry maps a fully qualified proto type name (e.g.
+// "examples.SingerInfo") to the concrete Go type backing it, so the online
+// serving path can deserialize ValueType_PROTO_MESSAGE join keys and
+// features without generated code depending on every user's proto package.
+// ValueType_PROTO_ENUM values are checked by wire type only and do not use
+// this registry.
+var (
+	protoRegistryMu sync.RWMutex
+	protoRegistry   = make(map[string]reflect.Type)
+)
+
+// RegisterProtoType registers msg's concrete Go type under fqn, so that
+// join keys or features declared with that fully qualified proto type name
+// can be validated and deserialized by the feature server. Applications
+// embedding the Go feature server should call this once at startup for
+// every proto message type used in ValueType_PROTO_MESSAGE fields.
+func RegisterProtoType(fqn string, msg proto.Message) error {
+	if fqn == "" {
+		return fmt.Errorf("RegisterProtoType: fqn must not be empty")
+	}
+	if msg == nil {
+		return fmt.Errorf("RegisterProtoType: msg must not be nil for %q", fqn)
+	}
+
+	protoRegistryMu.Lock()
+	defer protoRegistryMu.Unlock()
+	protoRegistry[fqn] = reflect.TypeOf(msg).Elem()
+	return nil
+}
+
+// newProtoMessage returns a fresh, zero-valued instance of the proto message
+// registered under fqn, or an error if fqn was never registered via
+// RegisterProtoType.
+func newProtoMessage(fqn string) (proto.Message, error) {
+	protoRegistryMu.RLock()
+	goType, ok := protoRegistry[fqn]
+	protoRegistryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("proto type %q is not registered, call RegisterProtoType first", fqn)
+	}
+
+	return reflect.New(goType).Interface().(proto.Message), nil
+}